@@ -0,0 +1,58 @@
+package action
+
+import (
+	"fmt"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+// ChartPathOptions carries the settings needed to locate and authenticate
+// against a chart source that isn't already present on disk: a chart
+// reference resolved against a repository index, or a direct chart
+// repository/registry URL. It mirrors Helm's own chart-path flags so that
+// charts behind basic auth, bearer tokens or mTLS can be fetched without the
+// caller pre-downloading them.
+type ChartPathOptions struct {
+	CaFile                string
+	CertFile              string
+	KeyFile               string
+	Keyring               string
+	Username              string
+	Password              string
+	PassCredentialsAll    bool
+	RepoURL               string
+	Version               string
+	InsecureSkipTLSverify bool
+	PlainHTTP             bool
+}
+
+// Locate resolves chartRef to a local path, downloading and verifying it
+// against the configured repository if it isn't already a path on disk.
+// Provenance is verified against Keyring when Keyring is set.
+func (o *ChartPathOptions) Locate(chartRef string) (string, error) {
+	helmOpts := action.ChartPathOptions{
+		CaFile:                o.CaFile,
+		CertFile:              o.CertFile,
+		KeyFile:               o.KeyFile,
+		Keyring:               o.Keyring,
+		Username:              o.Username,
+		Password:              o.Password,
+		PassCredentialsAll:    o.PassCredentialsAll,
+		RepoURL:               o.RepoURL,
+		Version:               o.Version,
+		InsecureSkipTLSverify: o.InsecureSkipTLSverify,
+		PlainHTTP:             o.PlainHTTP,
+		Verify:                o.Keyring != "",
+	}
+
+	settings := cli.New()
+	settings.RepositoryConfig = ""
+
+	path, err := helmOpts.LocateChart(chartRef, settings)
+	if err != nil {
+		return "", fmt.Errorf("locate chart %q: %w", chartRef, err)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,172 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/strvals"
+	"sigs.k8s.io/yaml"
+)
+
+// ChartRenderOptions configures a single ChartRender invocation.
+type ChartRenderOptions struct {
+	Chart            string
+	ReleaseName      string
+	ReleaseNamespace string
+
+	// ValuesSets are "--set"-style values, parsed with the classic Helm
+	// mini-DSL (dot-separated keys, comma-separated list/map entries).
+	ValuesSets []string
+
+	// ValuesSetsJSON are "--set-json"-style values: each entry is a
+	// "key=<json>" pair whose right-hand side is decoded as raw JSON.
+	ValuesSetsJSON []string
+
+	// ValuesSetsFile are "--set-file"-style values: each entry is a
+	// "key=path" pair whose value is the verbatim contents of the file at
+	// path.
+	ValuesSetsFile []string
+
+	// ValuesSetsLiteral are "--set-literal"-style values: each entry is a
+	// "key=value" pair whose right-hand side is taken as an uninterpreted
+	// string, with no comma-splitting or type coercion.
+	ValuesSetsLiteral []string
+
+	// Remote indicates that Chart is a chart reference/URL that must be
+	// resolved and downloaded rather than a path already present on disk.
+	// When true, ChartPathOptions controls how the chart is fetched.
+	Remote bool
+
+	// ChartPathOptions authenticates and locates the chart when Remote is
+	// true. Ignored otherwise.
+	ChartPathOptions
+
+	OutputNoPrint bool
+}
+
+// ChartRenderResult is the outcome of rendering a chart's templates against
+// the resolved values.
+type ChartRenderResult struct {
+	Resources []map[string]interface{}
+}
+
+// ChartRender renders the chart referenced by opts.Chart and returns the
+// decoded resources it produces.
+func ChartRender(ctx context.Context, opts ChartRenderOptions) (*ChartRenderResult, error) {
+	chartPath := opts.Chart
+	if opts.Remote {
+		path, err := opts.ChartPathOptions.Locate(opts.Chart)
+		if err != nil {
+			return nil, fmt.Errorf("locate remote chart: %w", err)
+		}
+
+		chartPath = path
+	}
+
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("load chart %q: %w", chartPath, err)
+	}
+
+	values, err := mergeValues(chrt, opts)
+	if err != nil {
+		return nil, fmt.Errorf("merge values: %w", err)
+	}
+
+	renderValues, err := chartutil.ToRenderValues(chrt, values, chartutil.ReleaseOptions{
+		Name:      opts.ReleaseName,
+		Namespace: opts.ReleaseNamespace,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build render values: %w", err)
+	}
+
+	rendered, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("render chart: %w", err)
+	}
+
+	result := &ChartRenderResult{}
+	for name, manifest := range rendered {
+		if len(manifest) == 0 {
+			continue
+		}
+
+		var resource map[string]interface{}
+		if err := yaml.Unmarshal([]byte(manifest), &resource); err != nil {
+			return nil, fmt.Errorf("unmarshal rendered manifest %q: %w", name, err)
+		}
+
+		if resource == nil {
+			continue
+		}
+
+		result.Resources = append(result.Resources, resource)
+	}
+
+	if !opts.OutputNoPrint {
+		for _, resource := range result.Resources {
+			out, err := yaml.Marshal(resource)
+			if err != nil {
+				return nil, fmt.Errorf("marshal resource: %w", err)
+			}
+
+			fmt.Fprintf(os.Stdout, "---\n%s", out)
+		}
+	}
+
+	return result, nil
+}
+
+// mergeValues combines the chart's own values.yaml with the caller-supplied
+// --set-style overrides. Overrides are applied in Helm's own precedence
+// order, weakest to strongest: --set-json, --set, --set-file,
+// --set-literal. Within each kind, entries are applied in the order given.
+func mergeValues(chrt *chart.Chart, opts ChartRenderOptions) (map[string]interface{}, error) {
+	values := chartutil.Values{}
+	for k, v := range chrt.Values {
+		values[k] = v
+	}
+
+	for _, set := range opts.ValuesSetsJSON {
+		if err := strvals.ParseJSON(set, values); err != nil {
+			return nil, fmt.Errorf("parse --set-json value %q: %w", set, err)
+		}
+	}
+
+	for _, set := range opts.ValuesSets {
+		if err := strvals.ParseInto(set, values); err != nil {
+			return nil, fmt.Errorf("parse --set value %q: %w", set, err)
+		}
+	}
+
+	for _, set := range opts.ValuesSetsFile {
+		if err := strvals.ParseIntoFile(set, values, readFileRunes); err != nil {
+			return nil, fmt.Errorf("parse --set-file value %q: %w", set, err)
+		}
+	}
+
+	for _, set := range opts.ValuesSetsLiteral {
+		if err := strvals.ParseLiteralInto(set, values); err != nil {
+			return nil, fmt.Errorf("parse --set-literal value %q: %w", set, err)
+		}
+	}
+
+	return values, nil
+}
+
+// readFileRunes is the RunesValueReader Helm's strvals package uses to
+// resolve --set-file paths to their file contents.
+func readFileRunes(rs []rune) (interface{}, error) {
+	bytes, err := os.ReadFile(string(rs))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(bytes), nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	commoncli "github.com/werf/common-go/pkg/cli"
+
+	"github.com/werf/nelm/pkg/action"
+)
+
+// ChartRepositoryFlagGroup groups the flags that control how a chart is
+// fetched from a remote repository.
+var ChartRepositoryFlagGroup = &commoncli.FlagGroup{
+	ID:       "chart-repository",
+	Title:    "Chart Repository",
+	Priority: 10,
+}
+
+// AddChartPathFlags registers the --chart-repo-* flags used to
+// authenticate and locate a remote chart, binding them to opts.
+func AddChartPathFlags(cmd *cobra.Command, opts *action.ChartPathOptions) error {
+	if err := commoncli.AddFlag(cmd, &opts.RepoURL, "chart-repo-url", "", "Chart repository URL", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-url: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.Version, "chart-repo-version", "", "Chart version constraint", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-version: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.Username, "chart-repo-username", "", "Chart repository username", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-username: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.Password, "chart-repo-password", "", "Chart repository password", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-password: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.PassCredentialsAll, "chart-repo-pass-credentials-all", false, "Pass credentials to all domains encountered during the chart fetch, not just the repository host", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-pass-credentials-all: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.CaFile, "chart-repo-ca-file", "", "Verify certificates of HTTPS-enabled chart repositories using this CA bundle", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+		Type:  commoncli.FlagTypeFile,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-ca-file: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.CertFile, "chart-repo-cert-file", "", "Identify HTTPS client using this SSL certificate file", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+		Type:  commoncli.FlagTypeFile,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-cert-file: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.KeyFile, "chart-repo-key-file", "", "Identify HTTPS client using this SSL key file", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+		Type:  commoncli.FlagTypeFile,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-key-file: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.Keyring, "chart-repo-keyring", "", "Location of a public keyring used to verify the chart's provenance", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+		Type:  commoncli.FlagTypeFile,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-keyring: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.InsecureSkipTLSverify, "chart-repo-insecure-skip-tls-verify", false, "Skip TLS certificate checks for the chart repository", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-insecure-skip-tls-verify: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.PlainHTTP, "chart-repo-plain-http", false, "Use insecure plain HTTP to connect to the chart repository", commoncli.AddFlagOptions{
+		Group: ChartRepositoryFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag chart-repo-plain-http: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	commoncli "github.com/werf/common-go/pkg/cli"
+
+	"github.com/werf/nelm/pkg/action"
+)
+
+// ValuesFlagGroup groups the flags that inject or override chart values.
+var ValuesFlagGroup = &commoncli.FlagGroup{
+	ID:       "values",
+	Title:    "Values",
+	Priority: 20,
+}
+
+// AddValuesFlags registers the --set, --set-json, --set-file and
+// --set-literal flags, binding them to opts.
+func AddValuesFlags(cmd *cobra.Command, opts *action.ChartRenderOptions) error {
+	if err := commoncli.AddFlag(cmd, &opts.ValuesSets, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)", commoncli.AddFlagOptions{
+		Group: ValuesFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag set: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.ValuesSetsJSON, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)", commoncli.AddFlagOptions{
+		Group: ValuesFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag set-json: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.ValuesSetsFile, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)", commoncli.AddFlagOptions{
+		Group: ValuesFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag set-file: %w", err)
+	}
+
+	if err := commoncli.AddFlag(cmd, &opts.ValuesSetsLiteral, "set-literal", []string{}, "Set a literal STRING value on the command line", commoncli.AddFlagOptions{
+		Group: ValuesFlagGroup,
+	}); err != nil {
+		return fmt.Errorf("add flag set-literal: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package chart_render_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/werf/nelm/pkg/action"
+)
+
+const testChartTarName = "test-chart-0.1.0.tgz"
+
+var _ = Describe("Chart Path Options", func() {
+	var (
+		chartYaml    []byte
+		chartTgz     []byte
+		helmHomeDir  string
+		prevCacheDir string
+		prevConfDir  string
+		prevDataDir  string
+	)
+
+	BeforeEach(func() {
+		chartYaml = []byte("apiVersion: v2\nname: test-chart\nversion: 0.1.0\n")
+		chartTgz = buildChartArchive(chartYaml)
+
+		// Point Helm's cache/config/data dirs at a throwaway location so
+		// Locate() can't read or write the host's real ~/.cache/helm.
+		var err error
+		helmHomeDir, err = os.MkdirTemp("", "nelm-helm-home-")
+		Expect(err).NotTo(HaveOccurred())
+
+		prevCacheDir, prevConfDir, prevDataDir = os.Getenv("HELM_CACHE_HOME"), os.Getenv("HELM_CONFIG_HOME"), os.Getenv("HELM_DATA_HOME")
+		Expect(os.Setenv("HELM_CACHE_HOME", filepath.Join(helmHomeDir, "cache"))).To(Succeed())
+		Expect(os.Setenv("HELM_CONFIG_HOME", filepath.Join(helmHomeDir, "config"))).To(Succeed())
+		Expect(os.Setenv("HELM_DATA_HOME", filepath.Join(helmHomeDir, "data"))).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.Setenv("HELM_CACHE_HOME", prevCacheDir)
+		os.Setenv("HELM_CONFIG_HOME", prevConfDir)
+		os.Setenv("HELM_DATA_HOME", prevDataDir)
+		os.RemoveAll(helmHomeDir)
+	})
+
+	Context("when the chart repository requires basic auth", func() {
+		It("downloads and locates the chart once authenticated", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				if !ok || username != "nelm" || password != "s3cr3t" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				serveChartRepo(w, r, server1URL(r), chartTgz)
+			}))
+			defer server.Close()
+
+			opts := action.ChartPathOptions{
+				RepoURL:  server.URL,
+				Version:  "0.1.0",
+				Username: "nelm",
+				Password: "s3cr3t",
+			}
+
+			// Nelm has no native bearer-token field; Username/Password are
+			// forwarded into Helm's own ChartPathOptions, which sends them
+			// as HTTP Basic auth.
+			path, err := opts.Locate("test-chart")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(BeAnExistingFile())
+		})
+	})
+
+	Context("when the chart repository requires mTLS", func() {
+		It("downloads and locates the chart using the client certificate", func() {
+			ca, serverCert, clientCertPath, clientKeyPath, caPath := newMTLSFixture()
+
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				serveChartRepo(w, r, "https://"+r.Host, chartTgz)
+			}))
+			server.TLS = &tls.Config{
+				Certificates: []tls.Certificate{serverCert},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    ca,
+			}
+			server.StartTLS()
+			defer server.Close()
+
+			opts := action.ChartPathOptions{
+				RepoURL:  server.URL,
+				Version:  "0.1.0",
+				CaFile:   caPath,
+				CertFile: clientCertPath,
+				KeyFile:  clientKeyPath,
+			}
+
+			path, err := opts.Locate("test-chart")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(BeAnExistingFile())
+		})
+	})
+})
+
+func server1URL(r *http.Request) string {
+	return fmt.Sprintf("http://%s", r.Host)
+}
+
+func serveChartRepo(w http.ResponseWriter, r *http.Request, baseURL string, chartTgz []byte) {
+	switch r.URL.Path {
+	case "/index.yaml":
+		index := fmt.Sprintf(`apiVersion: v1
+entries:
+  test-chart:
+    - name: test-chart
+      version: 0.1.0
+      urls:
+        - %s/%s
+`, baseURL, testChartTarName)
+		w.Write([]byte(index))
+	case "/" + testChartTarName:
+		w.Write(chartTgz)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func buildChartArchive(chartYaml []byte) []byte {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	_ = tw.WriteHeader(&tar.Header{Name: "test-chart/Chart.yaml", Size: int64(len(chartYaml)), Mode: 0644})
+	_, _ = tw.Write(chartYaml)
+
+	_ = tw.Close()
+	_ = gzw.Close()
+
+	return buf.Bytes()
+}
+
+// newMTLSFixture writes a throwaway CA, server certificate and client
+// certificate/key to temp files and returns them alongside the decoded CA
+// pool, so the httptest server and the chart path options under test can
+// both be configured for mutual TLS.
+func newMTLSFixture() (*x509.CertPool, tls.Certificate, string, string, string) {
+	dir, err := os.MkdirTemp("", "nelm-mtls-")
+	Expect(err).NotTo(HaveOccurred())
+
+	caCertPEM, caKeyPEM := generateTestCA()
+	serverCertPEM, serverKeyPEM := generateTestCert(caCertPEM, caKeyPEM, "127.0.0.1")
+	clientCertPEM, clientKeyPEM := generateTestCert(caCertPEM, caKeyPEM, "nelm-test-client")
+
+	caPath := filepath.Join(dir, "ca.pem")
+	Expect(os.WriteFile(caPath, caCertPEM, 0644)).To(Succeed())
+
+	clientCertPath := filepath.Join(dir, "client.pem")
+	Expect(os.WriteFile(clientCertPath, clientCertPEM, 0644)).To(Succeed())
+
+	clientKeyPath := filepath.Join(dir, "client-key.pem")
+	Expect(os.WriteFile(clientKeyPath, clientKeyPEM, 0600)).To(Succeed())
+
+	pool := x509.NewCertPool()
+	Expect(pool.AppendCertsFromPEM(caCertPEM)).To(BeTrue())
+
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pool, serverCert, clientCertPath, clientKeyPath, caPath
+}
+
+// generateTestCA returns a throwaway self-signed CA certificate and key, PEM
+// encoded.
+func generateTestCA() (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "nelm-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return encodeCert(der), encodeKey(key)
+}
+
+// generateTestCert issues a leaf certificate for commonName signed by the CA
+// produced by generateTestCA.
+func generateTestCert(caCertPEM, caKeyPEM []byte, commonName string) (certPEM, keyPEM []byte) {
+	caCertBlock, _ := pem.Decode(caCertPEM)
+	caCert, err := x509.ParseCertificate(caCertBlock.Bytes)
+	Expect(err).NotTo(HaveOccurred())
+
+	caKeyBlock, _ := pem.Decode(caKeyPEM)
+	caKey, err := x509.ParseECPrivateKey(caKeyBlock.Bytes)
+	Expect(err).NotTo(HaveOccurred())
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	Expect(err).NotTo(HaveOccurred())
+
+	return encodeCert(der), encodeKey(key)
+}
+
+func encodeCert(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func encodeKey(key *ecdsa.PrivateKey) []byte {
+	der, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
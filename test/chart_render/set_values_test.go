@@ -2,6 +2,7 @@ package chart_render_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -233,6 +234,110 @@ data:
 			Expect(resourceStr).To(ContainSubstring("value1,value2"))
 		})
 	})
+
+	Context("when using --set-json, --set-file and --set-literal", func() {
+		BeforeEach(func() {
+			simpleTemplate := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-config
+data:
+  {{- with .Values.name }}
+  name: {{ . | quote }}
+  {{- end }}
+  {{- with .Values.tags }}
+  tags: {{ . | toJson }}
+  {{- end }}
+  {{- with .Values.cert }}
+  cert: {{ . | quote }}
+  {{- end }}
+`
+			err := os.WriteFile(templateFile, []byte(simpleTemplate), 0644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("parses --set-json as raw JSON into a real array", func() {
+			opts := action.ChartRenderOptions{
+				Chart:            chartDir,
+				ReleaseName:      "test-release",
+				ReleaseNamespace: "test-namespace",
+				ValuesSetsJSON:   []string{`tags=["a","b"]`},
+				OutputNoPrint:    true,
+			}
+
+			result, err := action.ChartRender(ctx, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Resources).To(HaveLen(1))
+
+			// --set-json parses the value into a real []interface{}, not a string, so
+			// toJson renders it as a JSON array rather than a quoted string, and it
+			// decodes back into a list.
+			data, ok := result.Resources[0]["data"].(map[string]interface{})
+			Expect(ok).To(BeTrue())
+			Expect(data["tags"]).To(Equal([]interface{}{"a", "b"}))
+		})
+
+		It("reads --set-file contents verbatim, including special characters", func() {
+			// The rendered manifest round-trips through the chart's `quote` filter and a
+			// YAML unmarshal, which only preserves valid UTF-8 (e.g. raw non-UTF-8 bytes
+			// like 0xFF don't survive that round-trip intact regardless of how --set-file
+			// read them). Exercise verbatim reading with content that is valid UTF-8 but
+			// still awkward to pass through unescaped: newlines, quotes, a backslash and a
+			// non-ASCII rune.
+			fileContent := "line one\nline \"two\"\\with-backslash-☃"
+			certFile := filepath.Join(tempDir, "cert.txt")
+			Expect(os.WriteFile(certFile, []byte(fileContent), 0644)).To(Succeed())
+
+			opts := action.ChartRenderOptions{
+				Chart:            chartDir,
+				ReleaseName:      "test-release",
+				ReleaseNamespace: "test-namespace",
+				ValuesSetsFile:   []string{fmt.Sprintf("cert=%s", certFile)},
+				OutputNoPrint:    true,
+			}
+
+			result, err := action.ChartRender(ctx, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Resources).To(HaveLen(1))
+			Expect(result.Resources[0]["data"]).To(HaveKeyWithValue("cert", fileContent))
+		})
+
+		It("treats --set-literal as an uninterpreted string with no comma splitting", func() {
+			opts := action.ChartRenderOptions{
+				Chart:             chartDir,
+				ReleaseName:       "test-release",
+				ReleaseNamespace:  "test-namespace",
+				ValuesSetsLiteral: []string{"name=value1,value2"},
+				OutputNoPrint:     true,
+			}
+
+			result, err := action.ChartRender(ctx, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Resources).To(HaveLen(1))
+			Expect(result.Resources[0]["data"]).To(HaveKeyWithValue("name", "value1,value2"))
+		})
+
+		It("applies later flags over earlier ones: --set-json < --set < --set-file < --set-literal", func() {
+			fileValue := filepath.Join(tempDir, "name-from-file.txt")
+			Expect(os.WriteFile(fileValue, []byte("from-file"), 0644)).To(Succeed())
+
+			opts := action.ChartRenderOptions{
+				Chart:             chartDir,
+				ReleaseName:       "test-release",
+				ReleaseNamespace:  "test-namespace",
+				ValuesSets:        []string{"name=from-set"},
+				ValuesSetsLiteral: []string{"name=from-literal"},
+				ValuesSetsFile:    []string{fmt.Sprintf("name=%s", fileValue)},
+				ValuesSetsJSON:    []string{`name="from-json"`},
+				OutputNoPrint:     true,
+			}
+
+			result, err := action.ChartRender(ctx, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Resources).To(HaveLen(1))
+			Expect(result.Resources[0]["data"]).To(HaveKeyWithValue("name", "from-literal"))
+		})
+	})
 })
 
 func TestChartRenderSetValues(t *testing.T) {
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// ErrValueNotAllowed is returned when a flag, environment variable or config file value falls
+// outside its AllowedValues/AllowedValuesFunc set.
+type ErrValueNotAllowed struct {
+	Value   string
+	Allowed []string
+}
+
+func (e *ErrValueNotAllowed) Error() string {
+	return fmt.Sprintf("value %q is not allowed, must be one of: %s", e.Value, strings.Join(e.Allowed, ", "))
+}
+
+func checkAllowed(cmd *cobra.Command, allowedFunc func(cmd *cobra.Command) ([]string, error), values ...string) error {
+	allowed, err := allowedFunc(cmd)
+	if err != nil {
+		return fmt.Errorf("compute allowed values: %w", err)
+	}
+
+	for _, value := range values {
+		if !lo.Contains(allowed, value) {
+			return &ErrValueNotAllowed{Value: value, Allowed: allowed}
+		}
+	}
+
+	return nil
+}
+
+// validatingStringValue is a pflag.Value backing a *string flag that rejects any value outside
+// allowed(cmd), applied uniformly whether the value came from the CLI, an environment variable or
+// a config file (all three paths call Set on the same cmd.Flag(name).Value).
+type validatingStringValue struct {
+	dest    *string
+	cmd     *cobra.Command
+	allowed func(cmd *cobra.Command) ([]string, error)
+}
+
+func (v *validatingStringValue) String() string {
+	if v.dest == nil {
+		return ""
+	}
+
+	return *v.dest
+}
+
+func (v *validatingStringValue) Type() string {
+	return "string"
+}
+
+func (v *validatingStringValue) Set(s string) error {
+	if err := checkAllowed(v.cmd, v.allowed, s); err != nil {
+		return err
+	}
+
+	*v.dest = s
+
+	return nil
+}
+
+// validatingSliceValue wraps a splitSliceValue, validating every element produced by a split
+// before delegating to it, so each element of a []string flag is checked independently.
+type validatingSliceValue struct {
+	inner   *splitSliceValue
+	cmd     *cobra.Command
+	allowed func(cmd *cobra.Command) ([]string, error)
+}
+
+func (v *validatingSliceValue) String() string {
+	return v.inner.String()
+}
+
+func (v *validatingSliceValue) Type() string {
+	return v.inner.Type()
+}
+
+func (v *validatingSliceValue) Set(s string) error {
+	parts, err := v.inner.splitFunc(s)
+	if err != nil {
+		return fmt.Errorf("split value %q: %w", s, err)
+	}
+
+	if err := checkAllowed(v.cmd, v.allowed, parts...); err != nil {
+		return err
+	}
+
+	return v.inner.Set(s)
+}
+
+func (v *validatingSliceValue) Append(s string) error {
+	parts, err := v.inner.splitFunc(s)
+	if err != nil {
+		return fmt.Errorf("split value %q: %w", s, err)
+	}
+
+	if err := checkAllowed(v.cmd, v.allowed, parts...); err != nil {
+		return err
+	}
+
+	return v.inner.Append(s)
+}
+
+func (v *validatingSliceValue) Replace(vals []string) error {
+	for _, val := range vals {
+		parts, err := v.inner.splitFunc(val)
+		if err != nil {
+			return fmt.Errorf("split value %q: %w", val, err)
+		}
+
+		if err := checkAllowed(v.cmd, v.allowed, parts...); err != nil {
+			return err
+		}
+	}
+
+	return v.inner.Replace(vals)
+}
+
+func (v *validatingSliceValue) GetSlice() []string {
+	return v.inner.GetSlice()
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestAllowedValuesStringAccepted(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var output string
+	if err := AddFlag(cmd, &output, "output", "table", "Output format", AddFlagOptions{
+		AllowedValues: []string{"table", "json", "yaml"},
+	}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	if err := cmd.Flags().Set("output", "json"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	if output != "json" {
+		t.Fatalf("expected output %q, got %q", "json", output)
+	}
+}
+
+func TestAllowedValuesStringRejected(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var output string
+	if err := AddFlag(cmd, &output, "output", "table", "Output format", AddFlagOptions{
+		AllowedValues: []string{"table", "json", "yaml"},
+	}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	err := cmd.Flags().Set("output", "xml")
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var notAllowed *ErrValueNotAllowed
+	if !errors.As(err, &notAllowed) {
+		t.Fatalf("expected *ErrValueNotAllowed, got %v", err)
+	}
+
+	if notAllowed.Value != "xml" {
+		t.Fatalf("expected rejected value %q, got %q", "xml", notAllowed.Value)
+	}
+
+	if !strings.Contains(err.Error(), "table, json, yaml") {
+		t.Fatalf("expected error to list allowed values, got %q", err.Error())
+	}
+}
+
+func TestAllowedValuesSlicePerElement(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var envs []string
+	if err := AddFlag(cmd, &envs, "envs", nil, "Environments", AddFlagOptions{
+		SliceSplit:    SliceSplitCSV,
+		AllowedValues: []string{"dev", "stage", "prod"},
+	}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	if err := cmd.Flags().Set("envs", "dev,stage"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	if !equalStrings(envs, []string{"dev", "stage"}) {
+		t.Fatalf("unexpected envs: %v", envs)
+	}
+
+	err := cmd.Flags().Set("envs", "dev,qa")
+	if err == nil {
+		t.Fatal("expected an error for disallowed element, got nil")
+	}
+
+	var notAllowed *ErrValueNotAllowed
+	if !errors.As(err, &notAllowed) || notAllowed.Value != "qa" {
+		t.Fatalf("expected *ErrValueNotAllowed for %q, got %v", "qa", err)
+	}
+}
+
+func TestAllowedValuesBuildHelp(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var output string
+	if err := AddFlag(cmd, &output, "output", "table", "Output format", AddFlagOptions{
+		AllowedValues: []string{"table", "json", "yaml"},
+	}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	usage := cmd.Flag("output").Usage
+	if !strings.Contains(usage, "Allowed: table|json|yaml.") {
+		t.Fatalf("expected usage to document allowed values, got %q", usage)
+	}
+}
+
+func TestAllowedValuesCompletion(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var output string
+	if err := AddFlag(cmd, &output, "output", "table", "Output format", AddFlagOptions{
+		AllowedValues: []string{"table", "json", "yaml"},
+	}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	completionFunc, found := cmd.GetFlagCompletionFunc("output")
+	if !found {
+		t.Fatal("expected a registered completion func for --output")
+	}
+
+	completions, directive := completionFunc(cmd, nil, "")
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Fatalf("expected ShellCompDirectiveNoFileComp, got %v", directive)
+	}
+
+	if !equalStrings(completions, []string{"table", "json", "yaml"}) {
+		t.Fatalf("expected completions %v, got %v", []string{"table", "json", "yaml"}, completions)
+	}
+}
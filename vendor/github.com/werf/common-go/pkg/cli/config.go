@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigKeyFunc builds the dotted key a flag is read from in the configuration file.
+type ConfigKeyFunc func(cmd *cobra.Command, flagName string) string
+
+// ConfigFileResolver resolves flag values out of a parsed configuration file, addressed by the
+// same dotted keys ConfigKeyFunc produces (e.g. "chart.render.values_sets").
+type ConfigFileResolver interface {
+	// String resolves a scalar (bool, int, string, duration) value.
+	String(key string) (value string, found bool, err error)
+	// StringSlice resolves a []string-typed flag's value.
+	StringSlice(key string) (values []string, found bool, err error)
+	// StringMap resolves a map[string]string-typed flag's value.
+	StringMap(key string) (values map[string]string, found bool, err error)
+}
+
+// ConfigFlagGroup groups the --config flag itself.
+var ConfigFlagGroup = &FlagGroup{
+	ID:       "config",
+	Title:    "Configuration",
+	Priority: 0,
+}
+
+// AddConfigFlag registers the --config flag, which points AddFlag's configuration-file source at
+// an explicit file instead of the default discovery locations (./nelm.toml, ./nelm.yaml,
+// $XDG_CONFIG_HOME/nelm/config.toml).
+func AddConfigFlag(cmd *cobra.Command, dest *string) error {
+	return AddFlag(cmd, dest, "config", "", "Path to a nelm.toml or nelm.yaml configuration file", AddFlagOptions{
+		Group: ConfigFlagGroup,
+		Type:  FlagTypeFile,
+	})
+}
+
+// defaultConfigKeyFunc builds a key out of the flag's full command path, excluding the root
+// command itself (e.g. "nelm"), so "--values-sets" on "nelm chart render" becomes
+// "chart.render.values_sets".
+func defaultConfigKeyFunc(cmd *cobra.Command, flagName string) string {
+	var segments []string
+	for c := cmd; c != nil && c.HasParent(); c = c.Parent() {
+		segments = append([]string{c.Name()}, segments...)
+	}
+
+	segments = append(segments, strings.ReplaceAll(flagName, "-", "_"))
+
+	return strings.Join(segments, ".")
+}
+
+var (
+	configFileResolverOnce sync.Once
+	configFileResolverVal  ConfigFileResolver
+	configFileResolverErr  error
+)
+
+// getConfigFileResolver discovers and parses the configuration file (if any) the first time it's
+// called, and reuses the result for the lifetime of the process.
+func getConfigFileResolver() (ConfigFileResolver, error) {
+	configFileResolverOnce.Do(func() {
+		path := resolveConfigFilePath()
+		if path == "" {
+			return
+		}
+
+		configFileResolverVal, configFileResolverErr = newFileConfigResolver(path)
+	})
+
+	return configFileResolverVal, configFileResolverErr
+}
+
+func resolveConfigFilePath() string {
+	for i, arg := range os.Args {
+		switch {
+		case arg == "--config" && i+1 < len(os.Args):
+			return os.Args[i+1]
+		case strings.HasPrefix(arg, "--config="):
+			return strings.TrimPrefix(arg, "--config=")
+		}
+	}
+
+	if path := os.Getenv("NELM_CONFIG"); path != "" {
+		return path
+	}
+
+	candidates := []string{"nelm.toml", "nelm.yaml"}
+
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+		candidates = append(candidates, filepath.Join(xdgConfigHome, "nelm", "config.toml"))
+	} else if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".config", "nelm", "config.toml"))
+	}
+
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// fileConfigResolver decodes the config file into a generic map[string]interface{}, which loses
+// node position information. Its type-coercion errors (String/StringSlice/StringMap) therefore
+// carry the file path and dotted key but not a line number; getting one would require decoding
+// through an API that tracks source positions (e.g. toml.Tree) instead of a plain map.
+type fileConfigResolver struct {
+	path string
+	data map[string]interface{}
+}
+
+func newFileConfigResolver(path string) (*fileConfigResolver, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file %q: %w", path, err)
+	}
+
+	data := map[string]interface{}{}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".toml":
+		if err := toml.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("parse config file %q: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("parse config file %q: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("config file %q: unsupported extension %q, expected .toml, .yaml or .yml", path, ext)
+	}
+
+	return &fileConfigResolver{path: path, data: data}, nil
+}
+
+func (r *fileConfigResolver) lookup(key string) (interface{}, bool) {
+	var cur interface{} = r.data
+
+	for _, segment := range strings.Split(key, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		cur, ok = m[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+func (r *fileConfigResolver) String(key string) (string, bool, error) {
+	val, found := r.lookup(key)
+	if !found {
+		return "", false, nil
+	}
+
+	switch v := val.(type) {
+	case string:
+		return v, true, nil
+	case bool, int, int64, float64:
+		return fmt.Sprint(v), true, nil
+	default:
+		return "", false, fmt.Errorf("config file %q: key %q: expected a scalar value, got %T", r.path, key, val)
+	}
+}
+
+func (r *fileConfigResolver) StringSlice(key string) ([]string, bool, error) {
+	val, found := r.lookup(key)
+	if !found {
+		return nil, false, nil
+	}
+
+	items, ok := val.([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("config file %q: key %q: expected an array, got %T", r.path, key, val)
+	}
+
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false, fmt.Errorf("config file %q: key %q: expected an array of strings, got element of type %T", r.path, key, item)
+		}
+
+		result = append(result, s)
+	}
+
+	return result, true, nil
+}
+
+func (r *fileConfigResolver) StringMap(key string) (map[string]string, bool, error) {
+	val, found := r.lookup(key)
+	if !found {
+		return nil, false, nil
+	}
+
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("config file %q: key %q: expected a table, got %T", r.path, key, val)
+	}
+
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			return nil, false, fmt.Errorf("config file %q: key %q.%s: expected a string value, got %T", r.path, key, k, v)
+		}
+
+		result[k] = s
+	}
+
+	return result, true, nil
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// resetConfigFileResolver clears the cached resolver between test cases, since
+// getConfigFileResolver otherwise only ever runs its discovery once per process.
+func resetConfigFileResolver() {
+	configFileResolverOnce = sync.Once{}
+	configFileResolverVal = nil
+	configFileResolverErr = nil
+}
+
+func withWorkingDir(t *testing.T, dir string) {
+	t.Helper()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := os.Chdir(cwd); err != nil {
+			t.Fatalf("restore cwd: %v", err)
+		}
+	})
+}
+
+func TestConfigFilePrecedence(t *testing.T) {
+	dir := t.TempDir()
+	withWorkingDir(t, dir)
+
+	writeFile(t, filepath.Join(dir, "nelm.toml"), "[chart.render]\nrelease_name = \"from-toml\"\n")
+	writeFile(t, filepath.Join(dir, "nelm.yaml"), "chart:\n  render:\n    release_name: from-yaml\n")
+
+	resetConfigFileResolver()
+	t.Cleanup(resetConfigFileResolver)
+
+	resolver, err := getConfigFileResolver()
+	if err != nil {
+		t.Fatalf("getConfigFileResolver: %v", err)
+	}
+
+	value, found, err := resolver.String("chart.render.release_name")
+	if err != nil {
+		t.Fatalf("String: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected key to be found")
+	}
+
+	// nelm.toml is checked before nelm.yaml when both are present.
+	if value != "from-toml" {
+		t.Fatalf("expected %q, got %q", "from-toml", value)
+	}
+}
+
+func TestConfigFileValueOverriddenByCLIFlag(t *testing.T) {
+	dir := t.TempDir()
+	withWorkingDir(t, dir)
+
+	writeFile(t, filepath.Join(dir, "nelm.toml"), "[render]\nrelease_name = \"from-config\"\n")
+
+	resetConfigFileResolver()
+	t.Cleanup(resetConfigFileResolver)
+
+	cmd := newSubcommand("render")
+
+	var releaseName string
+	if err := AddFlag(cmd, &releaseName, "release-name", "default-name", "Release name", AddFlagOptions{}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	// Config file value wins over the flag default.
+	if releaseName != "from-config" {
+		t.Fatalf("expected %q, got %q", "from-config", releaseName)
+	}
+
+	if err := cmd.Flags().Set("release-name", "from-cli"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	// CLI flag wins over everything else.
+	if releaseName != "from-cli" {
+		t.Fatalf("expected %q, got %q", "from-cli", releaseName)
+	}
+}
+
+func TestConfigFileTypeCoercionFailure(t *testing.T) {
+	dir := t.TempDir()
+	withWorkingDir(t, dir)
+
+	writeFile(t, filepath.Join(dir, "nelm.toml"), "[render]\nvalues_sets = \"not-an-array\"\n")
+
+	resetConfigFileResolver()
+	t.Cleanup(resetConfigFileResolver)
+
+	cmd := newSubcommand("render")
+
+	var valuesSets []string
+	err := AddFlag(cmd, &valuesSets, "values-sets", nil, "Set values", AddFlagOptions{})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if got := err.Error(); !strings.Contains(got, "nelm.toml") || !strings.Contains(got, "render.values_sets") {
+		t.Fatalf("expected error to mention file and key, got %q", got)
+	}
+}
+
+func TestConfigFileMapValueWithCustomKVSeparator(t *testing.T) {
+	dir := t.TempDir()
+	withWorkingDir(t, dir)
+
+	writeFile(t, filepath.Join(dir, "nelm.toml"), "[render]\nlabels = { tier = \"front:end\" }\n")
+
+	resetConfigFileResolver()
+	t.Cleanup(resetConfigFileResolver)
+
+	cmd := newSubcommand("render")
+
+	var labels map[string]string
+	if err := AddFlag(cmd, &labels, "labels", nil, "Labels", AddFlagOptions{MapKVSeparator: ":"}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	// The config-file value is re-assembled with the flag's own ":"
+	// separator before being handed to Value.Set, so a value containing a
+	// ":" still round-trips intact instead of being mis-split.
+	if got := labels["tier"]; got != "front:end" {
+		t.Fatalf("expected %q, got %q", "front:end", got)
+	}
+}
+
+// newSubcommand returns cmd attached to a throwaway root command, so
+// defaultConfigKeyFunc treats cmd's own name as part of the config key
+// instead of stripping it as the program root.
+func newSubcommand(use string) *cobra.Command {
+	root := &cobra.Command{Use: "nelm"}
+	cmd := &cobra.Command{Use: use}
+	root.AddCommand(cmd)
+
+	return cmd
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
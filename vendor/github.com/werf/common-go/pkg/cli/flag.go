@@ -29,10 +29,38 @@ type AddFlagOptions struct {
 	// value. For slice and map-type flags: all env vars values and all cli flags values are joined.
 	GetEnvVarRegexesFunc GetFlagEnvVarRegexesInterface
 
+	// ConfigKeyFunc builds the dotted key this flag is read from in the config file source (see
+	// ConfigFileResolver). Defaults to the flag's command path (without the root command) plus its name, with dashes
+	// replaced by underscores, e.g. flag "values-sets" on `nelm chart render` becomes
+	// "chart.render.values_sets".
+	ConfigKeyFunc ConfigKeyFunc
+
 	// Group info is saved in Flag annotations, which can be used later, e.g. for grouping flags in
 	// the --help output.
 	Group *FlagGroup
 
+	// SliceSplit controls how a []string flag's inputs are expanded into elements. Defaults to
+	// SliceSplitNone (one input, one element), matching pflag.StringArrayVar. Applies uniformly to
+	// CLI, environment variable and config file inputs.
+	SliceSplit SliceSplit
+
+	// MapKVSeparator overrides the key/value separator used to parse a map[string]string flag's
+	// entries. Defaults to "=".
+	MapKVSeparator string
+
+	// AllowedValues restricts the flag to a fixed set of values. Only supported for *string and
+	// *[]string destinations; for the latter, every element is validated independently. The
+	// allowed set is validated against CLI, environment variable and config file input alike, and
+	// is cheap enough to also be surfaced in --help as "Allowed: foo|bar|baz".
+	AllowedValues []string
+
+	// AllowedValuesFunc computes the allowed set dynamically, e.g. existing release names, and
+	// takes priority over AllowedValues for validation and shell completion. Since it may hit a
+	// cluster or remote API, it is evaluated lazily — on validation and on completion requests —
+	// never just to build --help text; pair it with AllowedValues if you also want a static hint
+	// in --help.
+	AllowedValuesFunc func(cmd *cobra.Command) ([]string, error)
+
 	Type       FlagType
 	ShortName  string
 	Deprecated bool
@@ -40,10 +68,7 @@ type AddFlagOptions struct {
 	Required   bool
 }
 
-// TODO(ilya-lesikov): allow restricted values
-// TODO(ilya-lesikov): allow showing restricted values in usage
 // TODO(ilya-lesikov): pass examples separately from help
-// TODO(ilya-lesikov): allow for []string with no comma-separated values (pflag.StringArrayVar?)
 // TODO(ilya-lesikov): allow for map[string]string with no comma-separated values
 
 // Create and bind a flag to the Cobra command. Corresponding environment variables (if enabled)
@@ -60,12 +85,12 @@ func AddFlag[T any](cmd *cobra.Command, dest *T, name string, defaultValue T, he
 		return fmt.Errorf("get env var names: %w", err)
 	}
 
-	help, err = buildHelp(help, dest, envVarRegexExprs)
+	help, err = buildHelp(cmd, help, dest, envVarRegexExprs, opts)
 	if err != nil {
 		return fmt.Errorf("build help: %w", err)
 	}
 
-	if err := addFlags(cmd, dest, name, opts.ShortName, defaultValue, help); err != nil {
+	if err := addFlags(cmd, dest, name, defaultValue, help, opts); err != nil {
 		return fmt.Errorf("add flags: %w", err)
 	}
 
@@ -87,6 +112,10 @@ func AddFlag[T any](cmd *cobra.Command, dest *T, name string, defaultValue T, he
 		}
 	}
 
+	if err := processConfigFile(cmd, opts.ConfigKeyFunc, opts.MapKVSeparator, name, dest); err != nil {
+		return fmt.Errorf("process config file: %w", err)
+	}
+
 	if err := processEnvVars(cmd, envVarRegexExprs, name, dest); err != nil {
 		return fmt.Errorf("process env vars: %w", err)
 	}
@@ -108,6 +137,23 @@ func AddFlag[T any](cmd *cobra.Command, dest *T, name string, defaultValue T, he
 		}
 	}
 
+	if opts.AllowedValuesFunc != nil {
+		allowedValuesFunc := opts.AllowedValuesFunc
+
+		completionFunc := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			allowed, err := allowedValuesFunc(cmd)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return allowed, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		if err := cmd.RegisterFlagCompletionFunc(name, completionFunc); err != nil {
+			return fmt.Errorf("register flag completion: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -123,14 +169,57 @@ func applyAddOptionsDefaults[T any](opts AddFlagOptions, dest *T) (AddFlagOption
 		}
 	}
 
+	if opts.ConfigKeyFunc == nil {
+		opts.ConfigKeyFunc = defaultConfigKeyFunc
+	}
+
+	if opts.AllowedValuesFunc == nil && len(opts.AllowedValues) > 0 {
+		allowed := opts.AllowedValues
+		opts.AllowedValuesFunc = func(*cobra.Command) ([]string, error) {
+			return allowed, nil
+		}
+	}
+
+	if opts.AllowedValuesFunc != nil {
+		switch any(dest).(type) {
+		case *string, *[]string:
+		default:
+			return AddFlagOptions{}, fmt.Errorf("AllowedValues/AllowedValuesFunc is only supported for string and []string flags, got %T", dest)
+		}
+	}
+
 	return opts, nil
 }
 
-func buildHelp[T any](help string, dest *T, envVarRegexes []*FlagRegexExpr) (string, error) {
+func buildHelp[T any](cmd *cobra.Command, help string, dest *T, envVarRegexes []*FlagRegexExpr, opts AddFlagOptions) (string, error) {
 	if !strings.HasSuffix(help, ".") {
 		help += "."
 	}
 
+	switch any(dest).(type) {
+	case *[]string:
+		switch opts.SliceSplit {
+		case SliceSplitCSV:
+			help += " Comma-separated."
+		case SliceSplitShell:
+			help += " Shell-word-separated."
+		}
+	case *map[string]string:
+		sep := opts.MapKVSeparator
+		if sep == "" {
+			sep = "="
+		}
+
+		help += fmt.Sprintf(" Comma-separated key%svalue pairs.", sep)
+	}
+
+	// A static AllowedValues list is cheap to render here, but an AllowedValuesFunc may hit a
+	// cluster or remote API, so it's only evaluated lazily, through validation and shell
+	// completion, not on every command construction just to build --help text.
+	if len(opts.AllowedValues) > 0 {
+		help += fmt.Sprintf(" Allowed: %s.", strings.Join(opts.AllowedValues, "|"))
+	}
+
 	if len(envVarRegexes) == 0 {
 		return help, nil
 	} else if len(envVarRegexes) == 1 {
@@ -147,18 +236,59 @@ func buildHelp[T any](help string, dest *T, envVarRegexes []*FlagRegexExpr) (str
 	return help, nil
 }
 
-func addFlags[T any](cmd *cobra.Command, dest *T, name string, shortName string, defaultValue T, help string) error {
+func addFlags[T any](cmd *cobra.Command, dest *T, name string, defaultValue T, help string, opts AddFlagOptions) error {
+	shortName := opts.ShortName
+
 	switch dst := any(dest).(type) {
 	case *bool:
 		cmd.Flags().BoolVarP(dst, name, shortName, any(defaultValue).(bool), help)
 	case *int:
 		cmd.Flags().IntVarP(dst, name, shortName, any(defaultValue).(int), help)
 	case *string:
-		cmd.Flags().StringVarP(dst, name, shortName, any(defaultValue).(string), help)
+		if opts.AllowedValuesFunc != nil {
+			def := any(defaultValue).(string)
+			if def != "" {
+				if err := checkAllowed(cmd, opts.AllowedValuesFunc, def); err != nil {
+					return fmt.Errorf("default value for flag %q: %w", name, err)
+				}
+			}
+
+			*dst = def
+			cmd.Flags().VarP(&validatingStringValue{dest: dst, cmd: cmd, allowed: opts.AllowedValuesFunc}, name, shortName, help)
+		} else {
+			cmd.Flags().StringVarP(dst, name, shortName, any(defaultValue).(string), help)
+		}
 	case *[]string:
-		cmd.Flags().StringArrayVarP(dst, name, shortName, any(defaultValue).([]string), help)
+		def := any(defaultValue).([]string)
+
+		if opts.AllowedValuesFunc != nil {
+			if len(def) > 0 {
+				if err := checkAllowed(cmd, opts.AllowedValuesFunc, def...); err != nil {
+					return fmt.Errorf("default value for flag %q: %w", name, err)
+				}
+			}
+
+			*dst = def
+			inner := &splitSliceValue{dest: dst, splitFunc: sliceSplitFunc(opts.SliceSplit)}
+			cmd.Flags().VarP(&validatingSliceValue{inner: inner, cmd: cmd, allowed: opts.AllowedValuesFunc}, name, shortName, help)
+		} else {
+			switch opts.SliceSplit {
+			case SliceSplitCSV, SliceSplitShell:
+				*dst = def
+				cmd.Flags().VarP(&splitSliceValue{dest: dst, splitFunc: sliceSplitFunc(opts.SliceSplit)}, name, shortName, help)
+			default:
+				cmd.Flags().StringArrayVarP(dst, name, shortName, def, help)
+			}
+		}
 	case *map[string]string:
-		cmd.Flags().StringToStringVarP(dst, name, shortName, any(defaultValue).(map[string]string), help)
+		def := any(defaultValue).(map[string]string)
+
+		if opts.MapKVSeparator != "" && opts.MapKVSeparator != "=" {
+			*dst = def
+			cmd.Flags().VarP(&kvMapValue{dest: dst, kvSep: opts.MapKVSeparator}, name, shortName, help)
+		} else {
+			cmd.Flags().StringToStringVarP(dst, name, shortName, def, help)
+		}
 	case *time.Duration:
 		cmd.Flags().DurationVarP(dst, name, shortName, any(defaultValue).(time.Duration), help)
 	default:
@@ -246,6 +376,86 @@ func processEnvVars[T any](cmd *cobra.Command, envVarRegexExprs []*FlagRegexExpr
 	return nil
 }
 
+// processConfigFile resolves flagName's value from the discovered configuration file (if any) and
+// assigns it to dest, the same way processEnvVars assigns environment variable values. Runs before
+// processEnvVars, so env vars and CLI flags still take precedence over it. For []string and
+// map[string]string flags, config-file entries are appended to, matching the append semantics of
+// processEnvVars. mapKVSeparator is the flag's configured map key/value separator (empty means
+// the default "="), used to re-assemble map entries read from the config file before handing them
+// to the flag's Value.Set, which splits on that same separator.
+func processConfigFile[T any](cmd *cobra.Command, keyFunc ConfigKeyFunc, mapKVSeparator string, flagName string, dest T) error {
+	resolver, err := getConfigFileResolver()
+	if err != nil {
+		return fmt.Errorf("load config file: %w", err)
+	} else if resolver == nil {
+		return nil
+	}
+
+	if keyFunc == nil {
+		keyFunc = defaultConfigKeyFunc
+	}
+
+	key := keyFunc(cmd, flagName)
+
+	switch dst := any(dest).(type) {
+	case *bool, *int, *string, *time.Duration:
+		val, found, err := resolver.String(key)
+		if err != nil {
+			return fmt.Errorf("resolve config key %q: %w", key, err)
+		} else if !found {
+			return nil
+		}
+
+		flag := cmd.Flag(flagName)
+		flag.Changed = true
+
+		if err := flag.Value.Set(val); err != nil {
+			return fmt.Errorf("config key %q value %q is not valid: %w", key, val, err)
+		}
+	case *[]string:
+		vals, found, err := resolver.StringSlice(key)
+		if err != nil {
+			return fmt.Errorf("resolve config key %q: %w", key, err)
+		} else if !found {
+			return nil
+		}
+
+		flag := cmd.Flag(flagName)
+		flag.Changed = true
+
+		for _, val := range vals {
+			if err := flag.Value.(pflag.SliceValue).Append(val); err != nil {
+				return fmt.Errorf("config key %q value %q is not valid: %w", key, val, err)
+			}
+		}
+	case *map[string]string:
+		vals, found, err := resolver.StringMap(key)
+		if err != nil {
+			return fmt.Errorf("resolve config key %q: %w", key, err)
+		} else if !found {
+			return nil
+		}
+
+		flag := cmd.Flag(flagName)
+		flag.Changed = true
+
+		kvSep := mapKVSeparator
+		if kvSep == "" {
+			kvSep = "="
+		}
+
+		for k, v := range vals {
+			if err := flag.Value.Set(fmt.Sprintf("%s%s%s", k, kvSep, v)); err != nil {
+				return fmt.Errorf("config key %q value %q is not valid: %w", key, v, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type %T", dst)
+	}
+
+	return nil
+}
+
 func saveFlagGroupMetadata(cmd *cobra.Command, flagName string, group *FlagGroup) error {
 	if err := cmd.Flags().SetAnnotation(flagName, FlagGroupIDAnnotationName, []string{group.ID}); err != nil {
 		return fmt.Errorf("set group id annotation: %w", err)
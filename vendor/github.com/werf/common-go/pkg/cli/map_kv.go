@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// kvMapValue is a pflag.Value backing a map[string]string flag whose entries are separated by
+// commas (split via splitComma, so quoting matches SliceSplitCSV []string flags) and whose key and
+// value are separated by kvSep instead of pflag's hardcoded "=".
+type kvMapValue struct {
+	dest    *map[string]string
+	kvSep   string
+	changed bool
+}
+
+func (v *kvMapValue) String() string {
+	if v.dest == nil || len(*v.dest) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(*v.dest))
+	for k := range *v.dest {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+v.kvSep+(*v.dest)[k])
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func (v *kvMapValue) Set(s string) error {
+	parts, err := splitComma(s)
+	if err != nil {
+		return fmt.Errorf("split value %q: %w", s, err)
+	}
+
+	out := map[string]string{}
+	if v.changed {
+		for k, val := range *v.dest {
+			out[k] = val
+		}
+	}
+
+	for _, part := range parts {
+		kv := strings.SplitN(part, v.kvSep, 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid key%svalue pair %q, expected key%svalue", v.kvSep, part, v.kvSep)
+		}
+
+		out[kv[0]] = kv[1]
+	}
+
+	*v.dest = out
+	v.changed = true
+
+	return nil
+}
+
+func (v *kvMapValue) Type() string {
+	return "stringToString"
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SliceSplit controls how a single raw string (whether it comes from the CLI, an environment
+// variable, or a config file entry) is expanded into a []string flag's elements.
+type SliceSplit string
+
+const (
+	// SliceSplitNone takes each input verbatim as one element, performing no splitting. This is
+	// the default, matching pflag.StringArrayVar semantics.
+	SliceSplitNone SliceSplit = ""
+	// SliceSplitCSV splits each input on commas using splitComma's encoding/csv-based rules: a
+	// value containing a comma must be double-quoted (`"a,b"`), the same as any other CSV field.
+	// There is no backslash-escape support — `\,` is not treated specially and is not unescaped.
+	SliceSplitCSV SliceSplit = "csv"
+	// SliceSplitShell splits each input using shell word-splitting rules (whitespace-separated,
+	// with single/double quoting and backslash escapes).
+	SliceSplitShell SliceSplit = "shell"
+)
+
+// sliceSplitFunc returns the splitting function a SliceSplit mode uses, with SliceSplitNone
+// passing each input through unchanged as a single element.
+func sliceSplitFunc(s SliceSplit) func(string) ([]string, error) {
+	switch s {
+	case SliceSplitCSV:
+		return splitComma
+	case SliceSplitShell:
+		return splitShell
+	default:
+		return func(val string) ([]string, error) {
+			return []string{val}, nil
+		}
+	}
+}
+
+// splitSliceValue is a pflag.Value/pflag.SliceValue backing a []string flag whose inputs are
+// expanded by splitFunc before being appended to dest. The same splitFunc is reused by
+// processEnvVars and processConfigFile (via the SliceValue.Append call they already make), so CLI,
+// env var and config file inputs are all split the same way.
+type splitSliceValue struct {
+	dest      *[]string
+	splitFunc func(string) ([]string, error)
+	changed   bool
+}
+
+func (v *splitSliceValue) String() string {
+	if v.dest == nil {
+		return ""
+	}
+
+	return strings.Join(*v.dest, ",")
+}
+
+// Set is called by pflag once per "--flag value" occurrence on the command line. The first
+// occurrence replaces dest (so it overrides the default), later occurrences accumulate onto it —
+// the same two-call pattern pflag's own StringArray/StringSlice values use internally. Once Append
+// has run, every Set accumulates, so env var and config file values are kept.
+func (v *splitSliceValue) Set(s string) error {
+	parts, err := v.splitFunc(s)
+	if err != nil {
+		return fmt.Errorf("split value %q: %w", s, err)
+	}
+
+	if !v.changed {
+		*v.dest = parts
+	} else {
+		*v.dest = append(*v.dest, parts...)
+	}
+
+	v.changed = true
+
+	return nil
+}
+
+// Append is used by processEnvVars and processConfigFile to add one more raw value (split the
+// same way as Set) without disturbing whatever is already in dest.
+func (v *splitSliceValue) Append(s string) error {
+	parts, err := v.splitFunc(s)
+	if err != nil {
+		return fmt.Errorf("split value %q: %w", s, err)
+	}
+
+	*v.dest = append(*v.dest, parts...)
+	v.changed = true
+
+	return nil
+}
+
+func (v *splitSliceValue) Replace(vals []string) error {
+	var result []string
+
+	for _, val := range vals {
+		parts, err := v.splitFunc(val)
+		if err != nil {
+			return fmt.Errorf("split value %q: %w", val, err)
+		}
+
+		result = append(result, parts...)
+	}
+
+	*v.dest = result
+	v.changed = true
+
+	return nil
+}
+
+func (v *splitSliceValue) GetSlice() []string {
+	return *v.dest
+}
+
+func (v *splitSliceValue) Type() string {
+	return "stringArray"
+}
+
+// splitShell tokenizes s the way a shell would split an unquoted argument list: whitespace
+// separates words, and single/double quotes and backslash escapes can be used to include
+// whitespace or quote characters in a word.
+func splitShell(s string) ([]string, error) {
+	var (
+		tokens             []string
+		cur                strings.Builder
+		inSingle, inDouble bool
+		tokenStarted       bool
+	)
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		switch {
+		case inSingle:
+			if c == '\'' {
+				inSingle = false
+			} else {
+				cur.WriteRune(c)
+			}
+		case inDouble:
+			switch {
+			case c == '"':
+				inDouble = false
+			case c == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\'):
+				i++
+				cur.WriteRune(runes[i])
+			default:
+				cur.WriteRune(c)
+			}
+		case c == '\'':
+			inSingle = true
+			tokenStarted = true
+		case c == '"':
+			inDouble = true
+			tokenStarted = true
+		case c == '\\' && i+1 < len(runes):
+			i++
+			cur.WriteRune(runes[i])
+			tokenStarted = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if tokenStarted || cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				tokenStarted = false
+			}
+		default:
+			cur.WriteRune(c)
+			tokenStarted = true
+		}
+	}
+
+	if inSingle || inDouble {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+
+	if tokenStarted || cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+
+	return tokens, nil
+}
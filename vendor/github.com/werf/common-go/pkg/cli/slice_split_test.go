@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestSliceSplitCSVAppliesToCLIAndAppend(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var tags []string
+	if err := AddFlag(cmd, &tags, "tags", nil, "Tags", AddFlagOptions{SliceSplit: SliceSplitCSV}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	// A comma-containing value must be CSV-quoted; splitComma has no backslash-escape support.
+	if err := cmd.Flags().Set("tags", `a,"b,c"`); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	want := []string{"a", `b,c`}
+	if !equalStrings(tags, want) {
+		t.Fatalf("expected %v, got %v", want, tags)
+	}
+
+	// Same split must apply when appended the way processEnvVars/processConfigFile do.
+	flag := cmd.Flag("tags")
+	if err := flag.Value.(pflag.SliceValue).Append("d,e"); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	want = append(want, "d", "e")
+	if !equalStrings(tags, want) {
+		t.Fatalf("expected %v, got %v", want, tags)
+	}
+}
+
+func TestSliceSplitShell(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var args []string
+	if err := AddFlag(cmd, &args, "args", nil, "Args", AddFlagOptions{SliceSplit: SliceSplitShell}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	if err := cmd.Flags().Set("args", `--foo "bar baz" qux`); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	want := []string{"--foo", "bar baz", "qux"}
+	if !equalStrings(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
+
+func TestMapKVSeparator(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var labels map[string]string
+	if err := AddFlag(cmd, &labels, "labels", nil, "Labels", AddFlagOptions{MapKVSeparator: ":"}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	if err := cmd.Flags().Set("labels", "env:prod,team:platform"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	if labels["env"] != "prod" || labels["team"] != "platform" {
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+}
+
+func TestBuildHelpDocumentsSeparators(t *testing.T) {
+	cmd := newSubcommand("render")
+
+	var tags []string
+	if err := AddFlag(cmd, &tags, "tags", nil, "Tags", AddFlagOptions{SliceSplit: SliceSplitCSV}); err != nil {
+		t.Fatalf("AddFlag: %v", err)
+	}
+
+	usage := cmd.Flag("tags").Usage
+	if !strings.Contains(usage, "Comma-separated.") {
+		t.Fatalf("expected usage to document comma splitting, got %q", usage)
+	}
+}
+
+func equalStrings(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+
+	return true
+}